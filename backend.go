@@ -0,0 +1,185 @@
+package gittoolbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Status is a minimal snapshot of a working tree's sync state against its
+// upstream, as returned by Backend.Status.
+type Status struct {
+	Ahead     int
+	Behind    int
+	Untracked []string
+	Modified  []string
+	Staged    []string
+}
+
+// Backend abstracts the git operations gittoolbox needs, so callers can trade
+// the default subprocess-based implementation for a pure-Go one that doesn't
+// require git on PATH and doesn't pay fork/exec cost per call.
+type Backend interface {
+	// Log returns up to limit commits touching paths, most recent first.
+	// limit <= 0 means no limit.
+	Log(ctx context.Context, paths []string, limit int) ([]*CommitSummary, error)
+	Fetch(ctx context.Context, remote, ref string) error
+	Status(ctx context.Context) (*Status, error)
+	RevList(ctx context.Context, rangeSpec string) ([]string, error)
+	// ObjectFormat reports the repo's object hash format ("sha1" or
+	// "sha256"), which is fixed at `git init` time and never changes.
+	ObjectFormat(ctx context.Context) (string, error)
+	// ResolveRef resolves name (e.g. "main", "origin/main", "v1.2.3", "HEAD")
+	// the way `git rev-parse --symbolic-full-name` and `git rev-parse` would,
+	// returning the resolved full ref name (empty if name isn't a branch,
+	// remote branch, or tag) and the sha it points at.
+	ResolveRef(ctx context.Context, name string) (fullName, sha string, err error)
+	// WithDir returns a copy of the Backend rooted at dir, so callers that
+	// compute a target's working directory after construction (e.g.
+	// GetVersionCommit) can redirect an already-selected Backend without
+	// needing to know its concrete type.
+	WithDir(dir string) Backend
+}
+
+var defaultBackend Backend = ExecBackend{}
+
+// SetDefaultBackend changes the Backend used by package functions that don't
+// have one set via RunOpts.Backend.
+func SetDefaultBackend(b Backend) {
+	defaultBackend = b
+}
+
+func backendFor(opts *RunOpts) Backend {
+	if opts != nil && opts.Backend != nil {
+		return opts.Backend
+	}
+	return defaultBackend
+}
+
+// ExecBackend is the default Backend: it shells out to the git binary via
+// Command, the same way this package always has.
+type ExecBackend struct {
+	// Dir is the working directory git commands run in; empty means the
+	// current process directory.
+	Dir string
+}
+
+func (b ExecBackend) Log(ctx context.Context, paths []string, limit int) ([]*CommitSummary, error) {
+	args := []CmdArg{"log"}
+	if limit > 0 {
+		args = append(args, "-n", CmdArg(fmt.Sprintf("%d", limit)))
+	}
+	args = append(args, "--date=iso-strict", CmdArg("--pretty=format:"+gitLogFormat))
+	cmd := NewCommand(ctx, args...)
+	cmd.AddDashesAndList(paths...)
+	out, _, err := cmd.RunStdString(&RunOpts{Dir: b.Dir})
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+
+	lines := strings.Split(out, "\n")
+	summaries := make([]*CommitSummary, 0, len(lines))
+	for _, line := range lines {
+		cs, err := parseCommitSummary(line)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, cs)
+	}
+	return summaries, nil
+}
+
+func (b ExecBackend) Fetch(ctx context.Context, remote, ref string) error {
+	cmd := NewCommand(ctx, "fetch", "--quiet")
+	if err := cmd.AddDynamicArguments(remote, ref); err != nil {
+		return err
+	}
+	_, _, err := cmd.RunStdString(&RunOpts{Dir: b.Dir, Timeout: fetchTimeout})
+	return err
+}
+
+func (b ExecBackend) Status(ctx context.Context) (*Status, error) {
+	// Read the untrimmed stdout: RunStdString's trimmed copy would drop the
+	// leading space of a first line like " M a.txt".
+	var raw bytes.Buffer
+	if _, _, err := NewCommand(ctx, "status", "--porcelain").RunStdString(&RunOpts{Dir: b.Dir, Stdout: &raw}); err != nil {
+		return nil, err
+	}
+
+	st := &Status{}
+	for _, line := range strings.Split(raw.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		// Porcelain v1: two status chars, a space, then the path.
+		indexState, worktreeState, path := line[0], line[1], strings.TrimSpace(line[3:])
+		switch {
+		case indexState == '?' && worktreeState == '?':
+			st.Untracked = append(st.Untracked, path)
+		case indexState != ' ':
+			st.Staged = append(st.Staged, path)
+		default:
+			st.Modified = append(st.Modified, path)
+		}
+	}
+
+	aheadBehind, _, err := NewCommand(ctx, "rev-list", "--left-right", "--count", "@{upstream}...HEAD").RunStdString(&RunOpts{Dir: b.Dir})
+	if err == nil {
+		if parts := strings.Fields(aheadBehind); len(parts) == 2 {
+			fmt.Sscanf(parts[0], "%d", &st.Behind)
+			fmt.Sscanf(parts[1], "%d", &st.Ahead)
+		}
+	}
+
+	return st, nil
+}
+
+func (b ExecBackend) RevList(ctx context.Context, rangeSpec string) ([]string, error) {
+	cmd := NewCommand(ctx, "rev-list")
+	if err := cmd.AddDynamicArguments(rangeSpec); err != nil {
+		return nil, err
+	}
+	out, _, err := cmd.RunStdString(&RunOpts{Dir: b.Dir})
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+func (b ExecBackend) ObjectFormat(ctx context.Context) (string, error) {
+	return objectFormat(ctx, b.Dir)
+}
+
+func (b ExecBackend) ResolveRef(ctx context.Context, name string) (string, string, error) {
+	fullNameCmd := NewCommand(ctx, "rev-parse", "--symbolic-full-name")
+	if err := fullNameCmd.AddDynamicArguments(name); err != nil {
+		return "", "", err
+	}
+	fullName, _, err := fullNameCmd.RunStdString(&RunOpts{Dir: b.Dir})
+	if err != nil {
+		return "", "", err
+	}
+
+	shaCmd := NewCommand(ctx, "rev-parse")
+	if err := shaCmd.AddDynamicArguments(name); err != nil {
+		return "", "", err
+	}
+	sha, _, err := shaCmd.RunStdString(&RunOpts{Dir: b.Dir})
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(fullName), strings.TrimSpace(sha), nil
+}
+
+func (b ExecBackend) WithDir(dir string) Backend {
+	b.Dir = dir
+	return b
+}
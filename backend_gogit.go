@@ -0,0 +1,328 @@
+package gittoolbox
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// GoGitBackend implements Backend on top of go-git, without forking a git
+// process. It opens the repository once per call and walks history in
+// process, which is significantly cheaper than ExecBackend at high fan-out
+// (e.g. CI computing GetVersionCommit across dozens of subpackages).
+type GoGitBackend struct {
+	// Dir is the repository root (or any path inside it); empty means the
+	// current process directory.
+	Dir string
+}
+
+func (b GoGitBackend) open() (*gogit.Repository, error) {
+	dir := b.Dir
+	if dir == "" {
+		dir = "."
+	}
+	return gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{DetectDotGit: true})
+}
+
+func (b GoGitBackend) Log(ctx context.Context, paths []string, limit int) ([]*CommitSummary, error) {
+	repo, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+
+	// go-git reports paths relative to the worktree root, which lies above
+	// b.Dir when b.Dir is a subdirectory of the repository.
+	root := b.Dir
+	if wt, err := repo.Worktree(); err == nil {
+		root = wt.Filesystem.Root()
+	}
+	pathFilter, err := dirRelativePathFilter(root, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&gogit.LogOptions{PathFilter: pathFilter})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var out []*CommitSummary
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && len(out) >= limit {
+			return storer.ErrStop
+		}
+		out = append(out, commitSummaryFromGoGit(c))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (b GoGitBackend) Fetch(ctx context.Context, remote, ref string) error {
+	repo, err := b.open()
+	if err != nil {
+		return err
+	}
+
+	// Bound the fetch the same way ExecBackend.Fetch does: callers like
+	// GetStatus typically pass context.Background(), which alone would let a
+	// stalled connection hang forever.
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	refSpec := config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", ref, remote, ref))
+	err = repo.FetchContext(ctx, &gogit.FetchOptions{RemoteName: remote, RefSpecs: []config.RefSpec{refSpec}})
+	if err != nil && err != gogit.NoErrAlreadyUpToDate {
+		return err
+	}
+	return nil
+}
+
+func (b GoGitBackend) Status(ctx context.Context) (*Status, error) {
+	repo, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	wtStatus, err := wt.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ahead/behind counts require walking both local and upstream history;
+	// this package's consumers don't need them from the go-git backend yet,
+	// so they're left at zero here.
+	st := &Status{}
+	for path, s := range wtStatus {
+		switch {
+		case s.Worktree == gogit.Untracked:
+			st.Untracked = append(st.Untracked, path)
+		case s.Staging != gogit.Unmodified:
+			st.Staged = append(st.Staged, path)
+		case s.Worktree != gogit.Unmodified:
+			st.Modified = append(st.Modified, path)
+		}
+	}
+	sort.Strings(st.Untracked)
+	sort.Strings(st.Staged)
+	sort.Strings(st.Modified)
+	return st, nil
+}
+
+// RevList supports the two forms GetStatus and tests actually use: a bare
+// revision (all of its ancestors) or a two-dot range "from..to" (to's
+// ancestors excluding from's), matching `git rev-list`'s two-dot semantics.
+// repo.ResolveRevision doesn't understand ".." itself, so the range case is
+// resolved as a set difference between two independent ancestor walks.
+func (b GoGitBackend) RevList(ctx context.Context, rangeSpec string) ([]string, error) {
+	repo, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+
+	if from, to, ok := strings.Cut(rangeSpec, ".."); ok {
+		excluded, err := ancestorHashSet(repo, from)
+		if err != nil {
+			return nil, err
+		}
+		included, err := ancestorHashes(repo, to)
+		if err != nil {
+			return nil, err
+		}
+		hashes := make([]string, 0, len(included))
+		for _, h := range included {
+			if !excluded[h] {
+				hashes = append(hashes, h)
+			}
+		}
+		return hashes, nil
+	}
+
+	return ancestorHashes(repo, rangeSpec)
+}
+
+// ancestorHashes resolves revision and returns its hash plus every ancestor's
+// hash, most recent first.
+func ancestorHashes(repo *gogit.Repository, revision string) ([]string, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, err
+	}
+	iter, err := repo.Log(&gogit.LogOptions{From: *hash})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var hashes []string
+	err = iter.ForEach(func(c *object.Commit) error {
+		hashes = append(hashes, c.Hash.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// ancestorHashSet is ancestorHashes as a set, for the excluded side of a
+// two-dot range.
+func ancestorHashSet(repo *gogit.Repository, revision string) (map[string]bool, error) {
+	hashes, err := ancestorHashes(repo, revision)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		set[h] = true
+	}
+	return set, nil
+}
+
+// ObjectFormat reads the repo's object format straight out of its config,
+// rather than shelling out to `git rev-parse --show-object-format` the way
+// ExecBackend does — the whole point of GoGitBackend is avoiding that fork.
+func (b GoGitBackend) ObjectFormat(ctx context.Context) (string, error) {
+	repo, err := b.open()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", err
+	}
+	format := string(cfg.Extensions.ObjectFormat)
+	if format == "" {
+		format = "sha1"
+	}
+	return format, nil
+}
+
+func (b GoGitBackend) ResolveRef(ctx context.Context, name string) (string, string, error) {
+	repo, err := b.open()
+	if err != nil {
+		return "", "", err
+	}
+
+	if name == "HEAD" {
+		head, err := repo.Reference(plumbing.HEAD, false)
+		if err != nil {
+			return "", "", err
+		}
+		if head.Type() != plumbing.SymbolicReference {
+			return "HEAD", head.Hash().String(), nil
+		}
+		target := head.Target()
+		resolved, err := repo.Reference(target, true)
+		if err != nil {
+			return "", "", err
+		}
+		return string(target), resolved.Hash().String(), nil
+	}
+
+	for _, candidate := range []plumbing.ReferenceName{
+		plumbing.ReferenceName("refs/heads/" + name),
+		plumbing.ReferenceName("refs/remotes/" + name),
+		plumbing.ReferenceName("refs/tags/" + name),
+	} {
+		if ref, err := repo.Reference(candidate, true); err == nil {
+			return string(candidate), ref.Hash().String(), nil
+		}
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(name))
+	if err != nil {
+		return "", "", err
+	}
+	return "", hash.String(), nil
+}
+
+func (b GoGitBackend) WithDir(dir string) Backend {
+	b.Dir = dir
+	return b
+}
+
+func commitSummaryFromGoGit(c *object.Commit) *CommitSummary {
+	parents := make([]string, 0, len(c.ParentHashes))
+	for _, p := range c.ParentHashes {
+		parents = append(parents, p.String())
+	}
+
+	shortSha := c.Hash.String()
+	if len(shortSha) > 7 {
+		shortSha = shortSha[:7]
+	}
+
+	subject := c.Message
+	if i := strings.IndexByte(subject, '\n'); i >= 0 {
+		subject = subject[:i]
+	}
+
+	return &CommitSummary{
+		Sha:            c.Hash.String(),
+		ShortSha:       shortSha,
+		Parents:        parents,
+		CommitDate:     c.Committer.When,
+		AuthorDate:     c.Author.When,
+		AuthorName:     c.Author.Name,
+		AuthorEmail:    c.Author.Email,
+		CommitterName:  c.Committer.Name,
+		CommitterEmail: c.Committer.Email,
+		Subject:        subject,
+	}
+}
+
+// dirRelativePathFilter builds a go-git LogOptions.PathFilter matching
+// paths (which may be absolute or relative to the process's cwd) against
+// the repo-root-relative paths go-git reports. A path resolving to a
+// directory (the IncludeSubdirs case in resolveTargets) matches any file
+// reported under it, not just an exact hit.
+func dirRelativePathFilter(repoDir string, paths []string) (func(string) bool, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	base := repoDir
+	if base == "" {
+		base = "."
+	}
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return nil, err
+	}
+
+	rels := make([]string, 0, len(paths))
+	for _, p := range paths {
+		absP, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(absBase, absP)
+		if err != nil {
+			return nil, err
+		}
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	return func(path string) bool {
+		for _, rel := range rels {
+			if path == rel || strings.HasPrefix(path, rel+"/") {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
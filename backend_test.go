@@ -0,0 +1,158 @@
+package gittoolbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecBackend_Log(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	writeAndCommit(t, td, "a.txt", "v2", "2025-09-14 11:00:00")
+
+	b := ExecBackend{Dir: td}
+	commits, err := b.Log(context.Background(), []string{"a.txt"}, 0)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].Subject != "commit a.txt" {
+		t.Fatalf("unexpected subject: %+v", commits)
+	}
+}
+
+func TestExecBackend_Log_LimitsResults(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	writeAndCommit(t, td, "a.txt", "v2", "2025-09-14 11:00:00")
+
+	b := ExecBackend{Dir: td}
+	commits, err := b.Log(context.Background(), []string{"a.txt"}, 1)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 commit, got %d", len(commits))
+	}
+}
+
+func TestExecBackend_RevList(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	writeAndCommit(t, td, "a.txt", "v2", "2025-09-14 11:00:00")
+
+	b := ExecBackend{Dir: td}
+	shas, err := b.RevList(context.Background(), "HEAD")
+	if err != nil {
+		t.Fatalf("RevList failed: %v", err)
+	}
+	if len(shas) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(shas))
+	}
+}
+
+func TestExecBackend_Status_UntrackedFile(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	if err := writeFile(td+"/b.txt", "new"); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	b := ExecBackend{Dir: td}
+	st, err := b.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(st.Untracked) != 1 || st.Untracked[0] != "b.txt" {
+		t.Fatalf("expected b.txt untracked, got %+v", st)
+	}
+}
+
+func TestGoGitBackend_Log_DirectorySubtree(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	if err := os.MkdirAll(filepath.Join(td, "sub"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeAndCommit(t, td, filepath.Join("sub", "inner.txt"), "v1", "2025-09-14 10:00:00")
+
+	b := GoGitBackend{Dir: td}
+	commits, err := b.Log(context.Background(), []string{filepath.Join(td, "sub")}, 0)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 commit under sub/, got %d", len(commits))
+	}
+}
+
+func TestGoGitBackend_RevList_AheadBehindRange(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	runGit(t, td, "branch", "-m", "main")
+	runGit(t, td, "checkout", "-b", "origin-main")
+	writeAndCommit(t, td, "a.txt", "v2", "2025-09-14 11:00:00")
+	runGit(t, td, "checkout", "main")
+	writeAndCommit(t, td, "b.txt", "v1", "2025-09-14 12:00:00")
+	writeAndCommit(t, td, "b.txt", "v2", "2025-09-14 13:00:00")
+
+	b := GoGitBackend{Dir: td}
+	ahead, err := b.RevList(context.Background(), "origin-main..main")
+	if err != nil {
+		t.Fatalf("RevList ahead failed: %v", err)
+	}
+	if len(ahead) != 2 {
+		t.Fatalf("expected 2 commits ahead, got %d", len(ahead))
+	}
+
+	behind, err := b.RevList(context.Background(), "main..origin-main")
+	if err != nil {
+		t.Fatalf("RevList behind failed: %v", err)
+	}
+	if len(behind) != 1 {
+		t.Fatalf("expected 1 commit behind, got %d", len(behind))
+	}
+}
+
+func TestGoGitBackend_ObjectFormat_DefaultsToSHA1(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+
+	b := GoGitBackend{Dir: td}
+	format, err := b.ObjectFormat(context.Background())
+	if err != nil {
+		t.Fatalf("ObjectFormat failed: %v", err)
+	}
+	if format != "sha1" {
+		t.Fatalf("expected sha1, got %q", format)
+	}
+}
+
+func TestGoGitBackend_ResolveRef_LocalBranch(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+	runGit(t, td, "branch", "-m", "main")
+
+	b := GoGitBackend{Dir: td}
+	fullName, sha, err := b.ResolveRef(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("ResolveRef failed: %v", err)
+	}
+	if fullName != "refs/heads/main" {
+		t.Fatalf("expected refs/heads/main, got %q", fullName)
+	}
+	if sha == "" {
+		t.Fatalf("expected non-empty sha")
+	}
+}
@@ -0,0 +1,56 @@
+package gittoolbox
+
+import "fmt"
+
+// CmdArg is a trusted, literal git command-line argument or flag, e.g.
+// "log" or "--pretty=format:%H". Never build a CmdArg from unsanitized
+// input (file paths, branch names, user input) — a string starting with
+// "-" placed before "--" is interpreted as a flag by git, which has
+// historically enabled arbitrary-option injection (e.g. --upload-pack).
+// Use AddDynamicArguments or AddDashesAndList for anything not hard-coded
+// by this package.
+type CmdArg string
+
+// AddArguments appends one or more trusted literal arguments.
+func (c *Command) AddArguments(args ...CmdArg) *Command {
+	for _, a := range args {
+		c.args = append(c.args, string(a))
+	}
+	return c
+}
+
+// AddOptionValues appends a repeated "name value" pair for each value, e.g.
+// AddOptionValues("--date", "iso-strict") yields ["--date", "iso-strict"].
+// name is a trusted literal; values are not validated, so only use this for
+// values that are themselves trusted (enum-like flag arguments), not for
+// paths or other user-controlled strings.
+func (c *Command) AddOptionValues(name CmdArg, values ...string) *Command {
+	for _, v := range values {
+		c.args = append(c.args, string(name), v)
+	}
+	return c
+}
+
+// AddDashesAndList appends "--" followed by paths verbatim. "--" tells git
+// to stop parsing flags, so paths may safely contain any string, including
+// one starting with "-".
+func (c *Command) AddDashesAndList(paths ...string) *Command {
+	c.args = append(c.args, "--")
+	c.args = append(c.args, paths...)
+	return c
+}
+
+// AddDynamicArguments appends dynamic values (not preceded by "--") after
+// validating that none of them could be mistaken for a flag. It returns an
+// error instead of silently passing through a value starting with "-".
+func (c *Command) AddDynamicArguments(vals ...string) error {
+	for _, v := range vals {
+		if len(v) > 0 && v[0] == '-' {
+			return fmt.Errorf("dynamic argument %q must not start with '-'", v)
+		}
+	}
+	for _, v := range vals {
+		c.args = append(c.args, v)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package gittoolbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(path, content string) error {
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+func TestAddDynamicArguments_RejectsLeadingDash(t *testing.T) {
+	cases := []string{"-n", "--pretty=format:%H", "--upload-pack=evil"}
+	for _, v := range cases {
+		cmd := NewCommand(context.Background(), "log")
+		if err := cmd.AddDynamicArguments(v); err == nil {
+			t.Errorf("AddDynamicArguments(%q): expected error, got nil", v)
+		}
+	}
+}
+
+func TestAddDynamicArguments_AcceptsOrdinaryValues(t *testing.T) {
+	cmd := NewCommand(context.Background(), "log")
+	if err := cmd.AddDynamicArguments("main", "./-weird.txt-but-not-leading"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestGetVersionCommit_PathNamesLookLikeFlags proves that file names which
+// look like git flags (a leading "-") are treated as paths, not options,
+// because they're always appended after AddDashesAndList's "--".
+func TestGetVersionCommit_PathNamesLookLikeFlags(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+
+	const name = "-weird.txt"
+	path := filepath.Join(td, name)
+	if err := writeFile(path, "content"); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	// "git add -weird.txt" would itself misread the name as a flag, so add it
+	// via "--" the same way GetVersionCommit must handle it at log time.
+	runGit(t, td, "add", "--", name)
+	env := []string{"GIT_AUTHOR_DATE=2025-09-13 10:00:00", "GIT_COMMITTER_DATE=2025-09-13 10:00:00"}
+	runGitWithEnv(t, td, env, "commit", "-m", "commit "+name)
+
+	// Pass the bare relative name, so the argument git sees really does start
+	// with "-".
+	chdir(t, td)
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: name}}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetVersionCommit with dash-prefixed path failed: %v", err)
+	}
+	if cs.Subject != "commit "+name {
+		t.Fatalf("expected log for %s, got subject %q", name, cs.Subject)
+	}
+}
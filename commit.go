@@ -0,0 +1,82 @@
+package gittoolbox
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommitSummary describes a single commit, as returned by GetVersionCommit.
+type CommitSummary struct {
+	Sha            string
+	ShortSha       string
+	Parents        []string
+	CommitDate     time.Time
+	AuthorDate     time.Time
+	AuthorName     string
+	AuthorEmail    string
+	CommitterName  string
+	CommitterEmail string
+	Subject        string
+
+	// SuffixedDate is the commit date formatted as YYYY-MM-DD, with a
+	// "-b"/"-c"/"-d"/... suffix appended when more than one commit touched
+	// the requested paths on that date (two commits give "-b").
+	SuffixedDate string
+}
+
+// gitLogDelim separates fields within a single --pretty=format record. It uses
+// the ASCII unit separator, which cannot appear in any of the fields below.
+const gitLogDelim = "\x1f"
+
+// gitLogFormat is the --pretty=format string used by Backend.Log
+// implementations that shell out to git. Field order must match the parsing
+// in parseCommitSummary below.
+const gitLogFormat = "%H" + gitLogDelim + "%h" + gitLogDelim + "%P" + gitLogDelim +
+	"%cI" + gitLogDelim + "%aI" + gitLogDelim + "%an" + gitLogDelim + "%ae" +
+	gitLogDelim + "%cn" + gitLogDelim + "%ce" + gitLogDelim + "%s"
+
+func parseCommitSummary(line string) (*CommitSummary, error) {
+	fields := strings.Split(line, gitLogDelim)
+	if len(fields) != 10 {
+		return nil, fmt.Errorf("unexpected git log output: %d fields", len(fields))
+	}
+
+	commitDate, err := ParseGitDate(fields[3])
+	if err != nil {
+		return nil, fmt.Errorf("parse commit date: %w", err)
+	}
+	authorDate, err := ParseGitDate(fields[4])
+	if err != nil {
+		return nil, fmt.Errorf("parse author date: %w", err)
+	}
+
+	var parents []string
+	if fields[2] != "" {
+		parents = strings.Split(fields[2], " ")
+	}
+
+	return &CommitSummary{
+		Sha:            fields[0],
+		ShortSha:       fields[1],
+		Parents:        parents,
+		CommitDate:     commitDate,
+		AuthorDate:     authorDate,
+		AuthorName:     fields[5],
+		AuthorEmail:    fields[6],
+		CommitterName:  fields[7],
+		CommitterEmail: fields[8],
+		Subject:        fields[9],
+	}, nil
+}
+
+// ParseGitDate parses a Git commit timestamp. It accepts both --date=iso-strict
+// output (e.g. "2025-09-11T09:00:00+01:00") and Git's classic --date=iso layout
+// ("2006-01-02 15:04:05 -0700"), since the two are easy to mix up across call
+// sites and callers shouldn't have to care which one produced the string.
+func ParseGitDate(str string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, str); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04:05 -0700", str)
+}
@@ -0,0 +1,49 @@
+package gittoolbox
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetVersionCommit_Fields(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+
+	writeAndCommit(t, td, "a.txt", "hello", "2025-09-10 12:00:00")
+
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt")}}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetVersionCommit failed: %v", err)
+	}
+	if cs.Sha == "" || cs.ShortSha == "" {
+		t.Fatalf("expected non-empty Sha/ShortSha, got %+v", cs)
+	}
+	if cs.AuthorName != "Test" || cs.AuthorEmail != "test@example.com" {
+		t.Fatalf("unexpected author identity: %+v", cs)
+	}
+	if cs.Subject != "commit a.txt" {
+		t.Fatalf("unexpected subject: %q", cs.Subject)
+	}
+	if cs.SuffixedDate != "2025-09-10" {
+		t.Fatalf("expected SuffixedDate 2025-09-10, got %q", cs.SuffixedDate)
+	}
+	if len(cs.Parents) != 0 {
+		t.Fatalf("expected no parents for first commit, got %v", cs.Parents)
+	}
+}
+
+func TestParseGitDate(t *testing.T) {
+	cases := []string{
+		"2025-09-11T09:00:00+01:00",
+		"2025-09-11 09:00:00 +0100",
+	}
+	for _, c := range cases {
+		if _, err := ParseGitDate(c); err != nil {
+			t.Errorf("ParseGitDate(%q) failed: %v", c, err)
+		}
+	}
+	if _, err := ParseGitDate("not-a-date"); err == nil {
+		t.Errorf("expected error for invalid date")
+	}
+}
@@ -1,12 +1,12 @@
 package gittoolbox
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type PathTarget struct {
@@ -14,7 +14,114 @@ type PathTarget struct {
 	IncludeSubdirs bool
 }
 
-func GetVersionMetadata(targets []PathTarget) (string, string, error) {
+// GetVersionMetadata is a thin wrapper around GetVersionCommit for callers that
+// only need the suffixed commit date and short SHA.
+func GetVersionMetadata(ctx context.Context, targets []PathTarget, versionOpts *VersionOpts) (string, string, error) {
+	cs, err := GetVersionCommit(ctx, targets, nil, versionOpts)
+	if err != nil {
+		return "", "", err
+	}
+	return cs.SuffixedDate, cs.ShortSha, nil
+}
+
+// GetVersionCommit resolves targets to the most recent commit touching them
+// and returns a CommitSummary describing it. runOpts selects the Backend to
+// use (see RunOpts.Backend); pass nil to use the current default backend.
+// versionOpts controls how CommitSummary.ShortSha is abbreviated; pass nil to
+// use this package's default abbreviation for the repo's object format.
+func GetVersionCommit(ctx context.Context, targets []PathTarget, runOpts *RunOpts, versionOpts *VersionOpts) (*CommitSummary, error) {
+	resolved, workDir, err := resolveTargets(targets)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := backendFor(runOpts).WithDir(workDir)
+	var cs *CommitSummary
+	if eb, ok := backend.(ExecBackend); ok {
+		cs, err = getVersionCommitExec(ctx, eb, resolved)
+	} else {
+		cs, err = getVersionCommitBackend(ctx, backend, resolved)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyVersionOpts(ctx, backend, cs, versionOpts); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// getVersionCommitBackend implements GetVersionCommit for non-exec backends
+// (e.g. GoGitBackend), which compute the date-suffix count in a single
+// history walk rather than a second invocation.
+func getVersionCommitBackend(ctx context.Context, backend Backend, resolved []string) (*CommitSummary, error) {
+	commits, err := backend.Log(ctx, resolved, 0)
+	if err != nil {
+		return nil, fmt.Errorf("log: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found")
+	}
+
+	cs := commits[0]
+	commitDateNoSuffix := cs.CommitDate.Format("2006-01-02")
+	count := 0
+	for _, c := range commits {
+		if c.CommitDate.Format("2006-01-02") == commitDateNoSuffix {
+			count++
+		}
+	}
+	cs.SuffixedDate = suffixedDate(commitDateNoSuffix, count)
+	return cs, nil
+}
+
+// getVersionCommitExec implements GetVersionCommit for ExecBackend: a single
+// `git log -n 1` for the commit itself, plus one more to count same-date
+// commits for the suffix. resolved paths are always passed via
+// AddDashesAndList, never directly in the argument list, so a path like "-n"
+// or "--pretty=evil" can't be read as a flag.
+func getVersionCommitExec(ctx context.Context, backend ExecBackend, resolved []string) (*CommitSummary, error) {
+	commits, err := backend.Log(ctx, resolved, 1)
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found")
+	}
+	cs := commits[0]
+
+	countCmd := NewCommand(ctx, "log", "--date=format:%Y-%m-%d", "--pretty=format:%cd")
+	countCmd.AddDashesAndList(resolved...)
+	countStr, _, err := countCmd.RunStdString(&RunOpts{Dir: backend.Dir})
+	if err != nil {
+		return nil, fmt.Errorf("git log count: %w", err)
+	}
+
+	commitDateNoSuffix := cs.CommitDate.Format("2006-01-02")
+	count := 0
+	for _, line := range strings.Split(countStr, "\n") {
+		if strings.TrimSpace(line) == commitDateNoSuffix {
+			count++
+		}
+	}
+	cs.SuffixedDate = suffixedDate(commitDateNoSuffix, count)
+	return cs, nil
+}
+
+// suffixedDate formats dateNoSuffix (YYYY-MM-DD) with a "-b"/"-c"/"-d"/...
+// suffix when count (the number of commits sharing that date) is more than 1:
+// 2 commits give "-b", 3 give "-c", and so on. "-a" is never used.
+func suffixedDate(dateNoSuffix string, count int) string {
+	if count <= 1 {
+		return dateNoSuffix
+	}
+	return fmt.Sprintf("%s-%s", dateNoSuffix, string(rune('a'+count-1)))
+}
+
+// resolveTargets expands globs and directories in targets into a flat list of
+// file paths, plus the working directory git commands for them should run in.
+func resolveTargets(targets []PathTarget) ([]string, string, error) {
 	// default to current dir if nothing provided
 	if len(targets) == 0 {
 		targets = []PathTarget{{Path: "."}}
@@ -28,16 +135,16 @@ func GetVersionMetadata(targets []PathTarget) (string, string, error) {
 		if strings.ContainsAny(p, "*?[") {
 			matches, err := filepath.Glob(p)
 			if err != nil {
-				return "", "", fmt.Errorf("invalid glob %s: %w", p, err)
+				return nil, "", fmt.Errorf("invalid glob %s: %w", p, err)
 			}
 			if len(matches) == 0 {
-				return "", "", fmt.Errorf("glob %s: no matches", p)
+				return nil, "", fmt.Errorf("glob %s: no matches", p)
 			}
 			for _, m := range matches {
 				// treat each match like a target with the same IncludeSubdirs flag
 				info, err := os.Stat(m)
 				if err != nil {
-					return "", "", fmt.Errorf("stat %s: %w", m, err)
+					return nil, "", fmt.Errorf("stat %s: %w", m, err)
 				}
 				if info.IsDir() {
 					if t.IncludeSubdirs {
@@ -45,7 +152,7 @@ func GetVersionMetadata(targets []PathTarget) (string, string, error) {
 					} else {
 						entries, err := os.ReadDir(m)
 						if err != nil {
-							return "", "", fmt.Errorf("read dir %s: %w", m, err)
+							return nil, "", fmt.Errorf("read dir %s: %w", m, err)
 						}
 						for _, e := range entries {
 							if e.IsDir() {
@@ -65,7 +172,7 @@ func GetVersionMetadata(targets []PathTarget) (string, string, error) {
 		// non-glob: existing behavior
 		info, err := os.Stat(p)
 		if err != nil {
-			return "", "", fmt.Errorf("stat %s: %w", p, err)
+			return nil, "", fmt.Errorf("stat %s: %w", p, err)
 		}
 
 		if info.IsDir() {
@@ -75,7 +182,7 @@ func GetVersionMetadata(targets []PathTarget) (string, string, error) {
 			} else {
 				entries, err := os.ReadDir(p)
 				if err != nil {
-					return "", "", fmt.Errorf("read dir %s: %w", p, err)
+					return nil, "", fmt.Errorf("read dir %s: %w", p, err)
 				}
 				for _, e := range entries {
 					if e.IsDir() {
@@ -93,116 +200,20 @@ func GetVersionMetadata(targets []PathTarget) (string, string, error) {
 	}
 
 	if len(resolved) == 0 {
-		return "", "", fmt.Errorf("no files to inspect")
+		return nil, "", fmt.Errorf("no files to inspect")
 	}
 
 	// determine working directory for git commands: use directory of first resolved path
 	workDir := ""
-	if len(resolved) > 0 {
-		if info, err := os.Stat(resolved[0]); err == nil && !info.IsDir() {
-			workDir = filepath.Dir(resolved[0])
-		} else {
-			workDir = resolved[0]
-		}
-	}
-
-	// Build git args: e.g. git log -n 1 --pretty=format:%h -- <paths...>
-	baseArgsHash := []string{"log", "-n", "1", "--pretty=format:%h", "--"}
-	argsHash := append(baseArgsHash, resolved...)
-	commitHash, err := gitOutputIn(workDir, argsHash...)
-	if err != nil {
-		return "", "", fmt.Errorf("git log hash: %w", err)
-	}
-
-	// Get latest commit date (YYYY-MM-DD)
-	baseArgsDate := []string{"log", "-n", "1", "--date=format:%Y-%m-%d", "--pretty=format:%cd", "--"}
-	argsDate := append(baseArgsDate, resolved...)
-	commitDateNoSuffix, err := gitOutputIn(workDir, argsDate...)
-	if err != nil {
-		return "", "", fmt.Errorf("git log date: %w", err)
-	}
-
-	// Count commits on that date for the same set of paths
-	baseArgsCount := []string{"log", "--date=format:%Y-%m-%d", "--pretty=format:%cd", "--"}
-	argsCount := append(baseArgsCount, resolved...)
-	countStr, err := gitOutputIn(workDir, argsCount...)
-	if err != nil {
-		return "", "", fmt.Errorf("git log count: %w", err)
-	}
-
-	count := 0
-	for _, line := range strings.Split(countStr, "\n") {
-		if strings.TrimSpace(line) == commitDateNoSuffix {
-			count++
-		}
-	}
-
-	// Suffix: a for first, b for second, etc.
-	suffix := ""
-	if count > 1 {
-		// a=1, b=2, c=3, ...
-		suffix = string(rune('a' + count - 1))
-	}
-
-	var commitDate string
-	if suffix == "" || suffix == "a" {
-		commitDate = commitDateNoSuffix
+	if info, err := os.Stat(resolved[0]); err == nil && !info.IsDir() {
+		workDir = filepath.Dir(resolved[0])
 	} else {
-		commitDate = fmt.Sprintf("%s-%s", commitDateNoSuffix, suffix)
+		workDir = resolved[0]
 	}
 
-	return commitDate, commitHash, nil
+	return resolved, workDir, nil
 }
 
-func AssertBranchIsCleanAndSynced() error {
-	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
-	if err != nil {
-		return err
-	}
-	branch = strings.TrimSpace(branch)
-
-	if _, err := gitOutput("fetch", "origin", branch, "--quiet"); err != nil {
-		return err
-	}
-
-	status, err := gitOutput("rev-list", "--left-right", "--count", "origin/"+branch+"..."+branch)
-	if err != nil {
-		return err
-	}
-	parts := strings.Fields(status)
-	if len(parts) != 2 {
-		return fmt.Errorf("unexpected output from git rev-list")
-	}
-	if parts[0] != "0" {
-		return fmt.Errorf("your branch is behind origin/%s", branch)
-	}
-	if parts[1] != "0" {
-		return fmt.Errorf("your branch is ahead of origin/%s", branch)
-	}
-
-	changes, err := gitOutput("status", "--porcelain")
-	if err != nil {
-		return err
-	}
-	if strings.TrimSpace(changes) != "" {
-		return fmt.Errorf("you have uncommitted changes")
-	}
-	return nil
-}
-
-func gitOutput(args ...string) (string, error) {
-	return gitOutputIn("", args...)
-}
-
-// gitOutputIn runs git with args; if dir is non-empty it sets cmd.Dir so git runs in that directory.
-func gitOutputIn(dir string, args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &out
-	err := cmd.Run()
-	return strings.TrimSpace(out.String()), err
-}
+// fetchTimeout bounds `git fetch`, which talks to the network and would
+// otherwise hang for the full DefaultTimeout on a stalled connection.
+const fetchTimeout = 30 * time.Second
@@ -1,6 +1,7 @@
 package gittoolbox
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -60,7 +61,7 @@ func TestGetVersionMetadata_SingleFile(t *testing.T) {
 	// single commit on 2025-09-10 (use date/time string without timezone)
 	writeAndCommit(t, td, "a.txt", "hello", "2025-09-10 12:00:00")
 
-	commitDate, commitHash, err := GetVersionMetadata([]PathTarget{{Path: filepath.Join(td, "a.txt"), IncludeSubdirs: false}})
+	commitDate, commitHash, err := GetVersionMetadata(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt"), IncludeSubdirs: false}}, nil)
 	if err != nil {
 		t.Fatalf("GetVersionMetadata failed: %v", err)
 	}
@@ -83,7 +84,7 @@ func TestGetVersionMetadata_MultipleCommits_Suffix(t *testing.T) {
 	// second commit same date
 	writeAndCommit(t, td, "b.txt", "second", date)
 
-	commitDate, commitHash, err := GetVersionMetadata([]PathTarget{{Path: filepath.Join(td, "b.txt"), IncludeSubdirs: false}})
+	commitDate, commitHash, err := GetVersionMetadata(context.Background(), []PathTarget{{Path: filepath.Join(td, "b.txt"), IncludeSubdirs: false}}, nil)
 	if err != nil {
 		t.Fatalf("GetVersionMetadata failed: %v", err)
 	}
@@ -111,7 +112,7 @@ func TestGetVersionMetadata_GlobAndDirIncludeSubdirs(t *testing.T) {
 	writeAndCommit(t, td, filepath.ToSlash(filepath.Join("sub", "inner.txt")), "inner", "2025-09-12 09:00:00")
 
 	// glob should pick up root.txt
-	commitDate, commitHash, err := GetVersionMetadata([]PathTarget{{Path: filepath.Join(td, "*.txt"), IncludeSubdirs: false}})
+	commitDate, commitHash, err := GetVersionMetadata(context.Background(), []PathTarget{{Path: filepath.Join(td, "*.txt"), IncludeSubdirs: false}}, nil)
 	if err != nil {
 		t.Fatalf("GetVersionMetadata with glob failed: %v", err)
 	}
@@ -120,7 +121,7 @@ func TestGetVersionMetadata_GlobAndDirIncludeSubdirs(t *testing.T) {
 	}
 
 	// directory with IncludeSubdirs true should see nested file commits
-	commitDate2, commitHash2, err := GetVersionMetadata([]PathTarget{{Path: td, IncludeSubdirs: true}})
+	commitDate2, commitHash2, err := GetVersionMetadata(context.Background(), []PathTarget{{Path: td, IncludeSubdirs: true}}, nil)
 	if err != nil {
 		t.Fatalf("GetVersionMetadata with dir IncludeSubdirs failed: %v", err)
 	}
@@ -129,12 +130,29 @@ func TestGetVersionMetadata_GlobAndDirIncludeSubdirs(t *testing.T) {
 	}
 }
 
+func TestGetVersionCommit_GoGitBackend_UnrelatedCwd(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "hello", "2025-09-10 12:00:00")
+
+	cwd := t.TempDir()
+	chdir(t, cwd)
+
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt")}}, &RunOpts{Backend: GoGitBackend{}}, nil)
+	if err != nil {
+		t.Fatalf("GetVersionCommit failed: %v", err)
+	}
+	if cs.ShortSha == "" {
+		t.Fatalf("expected non-empty ShortSha")
+	}
+}
+
 func TestGetVersionMetadata_NoMatchesGlob(t *testing.T) {
 	td := t.TempDir()
 	initRepo(t, td)
 
 	// glob that matches nothing should return an error
-	_, _, err := GetVersionMetadata([]PathTarget{{Path: filepath.Join(td, "no-such-*.txt"), IncludeSubdirs: false}})
+	_, _, err := GetVersionMetadata(context.Background(), []PathTarget{{Path: filepath.Join(td, "no-such-*.txt"), IncludeSubdirs: false}}, nil)
 	if err == nil {
 		t.Fatalf("expected error for glob with no matches")
 	}
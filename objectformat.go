@@ -0,0 +1,123 @@
+package gittoolbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Hex lengths for the object ID formats git supports.
+const (
+	SHA1HexSize   = 40
+	SHA256HexSize = 64
+)
+
+// ObjectIDLengths lists the hex lengths of every object ID format git
+// supports, for callers validating a hash of unknown origin.
+var ObjectIDLengths = []int{SHA1HexSize, SHA256HexSize}
+
+// IsValidObjectID reports whether s has the hex length of a full SHA-1 or
+// SHA-256 object ID. It does not verify s is a real object in any repo.
+func IsValidObjectID(s string) bool {
+	if !isHex(s) {
+		return false
+	}
+	for _, n := range ObjectIDLengths {
+		if len(s) == n {
+			return true
+		}
+	}
+	return false
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	objectFormatCacheMu sync.Mutex
+	objectFormatCache   = map[string]string{}
+)
+
+// objectFormat returns the object format ("sha1" or "sha256") of the repo
+// containing workDir, caching the result per directory since a repository's
+// object format is fixed at `git init` time and never changes.
+func objectFormat(ctx context.Context, workDir string) (string, error) {
+	objectFormatCacheMu.Lock()
+	format, cached := objectFormatCache[workDir]
+	objectFormatCacheMu.Unlock()
+	if cached {
+		return format, nil
+	}
+
+	out, _, err := NewCommand(ctx, "rev-parse", "--show-object-format").RunStdString(&RunOpts{Dir: workDir})
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --show-object-format: %w", err)
+	}
+	format = strings.TrimSpace(out)
+
+	objectFormatCacheMu.Lock()
+	objectFormatCache[workDir] = format
+	objectFormatCacheMu.Unlock()
+	return format, nil
+}
+
+// defaultAbbrevLen is this package's default abbreviated-SHA length for the
+// given object format: long enough to stay unique in large monorepos without
+// printing a full hash.
+func defaultAbbrevLen(format string) int {
+	if format == "sha256" {
+		return 16
+	}
+	return 12
+}
+
+// VersionOpts customizes how GetVersionCommit renders a commit's hash.
+type VersionOpts struct {
+	// AbbrevLen overrides the abbreviated SHA length; 0 means the default
+	// for the repo's object format (12 for SHA-1, 16 for SHA-256).
+	AbbrevLen int
+	// FullHash, if true, sets ShortSha to the full SHA instead of an
+	// abbreviation.
+	FullHash bool
+}
+
+// applyVersionOpts sets cs.ShortSha to the hash length requested by opts,
+// defaulting to this package's own abbreviation (12 for SHA-1, 16 for
+// SHA-256) when opts is nil or leaves AbbrevLen unset. It only asks backend
+// for the object format when it actually needs it, so FullHash and an
+// explicit AbbrevLen stay free of that call entirely.
+func applyVersionOpts(ctx context.Context, backend Backend, cs *CommitSummary, opts *VersionOpts) error {
+	if opts == nil {
+		opts = &VersionOpts{}
+	}
+	if opts.FullHash {
+		cs.ShortSha = cs.Sha
+		return nil
+	}
+
+	abbrevLen := opts.AbbrevLen
+	if abbrevLen == 0 {
+		format, err := backend.ObjectFormat(ctx)
+		if err != nil {
+			return err
+		}
+		abbrevLen = defaultAbbrevLen(format)
+	}
+	if abbrevLen > len(cs.Sha) {
+		abbrevLen = len(cs.Sha)
+	}
+	cs.ShortSha = cs.Sha[:abbrevLen]
+	return nil
+}
@@ -0,0 +1,77 @@
+package gittoolbox
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsValidObjectID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"sha1", strings.Repeat("a", SHA1HexSize), true},
+		{"sha256", strings.Repeat("a", SHA256HexSize), true},
+		{"tooShort", strings.Repeat("a", 39), false},
+		{"nonHex", strings.Repeat("g", SHA1HexSize), false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidObjectID(tt.id); got != tt.want {
+				t.Fatalf("IsValidObjectID(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetVersionCommit_FullHash(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt")}}, nil, &VersionOpts{FullHash: true})
+	if err != nil {
+		t.Fatalf("GetVersionCommit failed: %v", err)
+	}
+	if cs.ShortSha != cs.Sha {
+		t.Fatalf("expected ShortSha to equal full Sha, got %q vs %q", cs.ShortSha, cs.Sha)
+	}
+	if len(cs.ShortSha) != SHA1HexSize {
+		t.Fatalf("expected a %d-char SHA-1, got %d chars", SHA1HexSize, len(cs.ShortSha))
+	}
+}
+
+func TestGetVersionCommit_NilVersionOptsUsesPackageDefault(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt")}}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetVersionCommit failed: %v", err)
+	}
+	if len(cs.ShortSha) != 12 {
+		t.Fatalf("expected nil VersionOpts to fall back to the 12-char SHA-1 default, got %d chars (%q)", len(cs.ShortSha), cs.ShortSha)
+	}
+}
+
+func TestGetVersionCommit_CustomAbbrevLen(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "v1", "2025-09-14 10:00:00")
+
+	cs, err := GetVersionCommit(context.Background(), []PathTarget{{Path: filepath.Join(td, "a.txt")}}, nil, &VersionOpts{AbbrevLen: 20})
+	if err != nil {
+		t.Fatalf("GetVersionCommit failed: %v", err)
+	}
+	if len(cs.ShortSha) != 20 {
+		t.Fatalf("expected a 20-char ShortSha, got %d chars (%q)", len(cs.ShortSha), cs.ShortSha)
+	}
+	if !strings.HasPrefix(cs.Sha, cs.ShortSha) {
+		t.Fatalf("expected ShortSha %q to be a prefix of Sha %q", cs.ShortSha, cs.Sha)
+	}
+}
@@ -0,0 +1,199 @@
+package gittoolbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RefType classifies the kind of ref ResolveRef resolved.
+type RefType int
+
+const (
+	RefTypeOther RefType = iota
+	RefTypeLocalBranch
+	RefTypeRemoteBranch
+	RefTypeLocalTag
+	RefTypeRemoteTag
+	RefTypeHEAD
+)
+
+func (t RefType) String() string {
+	switch t {
+	case RefTypeLocalBranch:
+		return "local branch"
+	case RefTypeRemoteBranch:
+		return "remote branch"
+	case RefTypeLocalTag:
+		return "local tag"
+	case RefTypeRemoteTag:
+		return "remote tag"
+	case RefTypeHEAD:
+		return "HEAD"
+	default:
+		return "other"
+	}
+}
+
+// Ref identifies a single git ref: a branch, tag, or HEAD.
+type Ref struct {
+	Name string
+	Type RefType
+	Sha  string
+}
+
+// ResolveRef resolves name (e.g. "main", "origin/main", "v1.2.3", "HEAD") to
+// the Ref it currently points at, using the current default Backend (see
+// SetDefaultBackend).
+func ResolveRef(ctx context.Context, name string) (*Ref, error) {
+	fullName, sha, err := backendFor(nil).ResolveRef(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("resolve ref %q: %w", name, err)
+	}
+	return &Ref{Name: name, Type: classifyRefType(name, fullName), Sha: sha}, nil
+}
+
+// classifyRefType uses name (the caller's original input) rather than
+// fullName alone, because --symbolic-full-name resolves "HEAD" through to
+// the branch it points at when attached, losing the fact that the caller
+// asked for HEAD specifically.
+func classifyRefType(name, fullName string) RefType {
+	switch {
+	case name == "HEAD":
+		return RefTypeHEAD
+	case strings.HasPrefix(fullName, "refs/heads/"):
+		return RefTypeLocalBranch
+	case strings.HasPrefix(fullName, "refs/remotes/"):
+		return RefTypeRemoteBranch
+	case strings.HasPrefix(fullName, "refs/tags/"):
+		return RefTypeLocalTag
+	default:
+		return RefTypeOther
+	}
+}
+
+// WorkingTreeStatus describes a working tree's cleanliness and its sync
+// state against its upstream.
+type WorkingTreeStatus struct {
+	Ahead     int
+	Behind    int
+	Untracked []string
+	Modified  []string
+	Staged    []string
+	Upstream  *Ref
+}
+
+// StatusCheck selects which parts of GetStatus's work to perform.
+// Zero value (StatusCheck(0)) is treated as CheckAll.
+type StatusCheck int
+
+const (
+	CheckWorkingTree StatusCheck = 1 << iota
+	CheckSynced
+	CheckAll = CheckWorkingTree | CheckSynced
+)
+
+// StatusOpts controls how GetStatus gathers a WorkingTreeStatus.
+type StatusOpts struct {
+	// Remote is the remote to compare against when CheckSynced is set.
+	// Defaults to "origin".
+	Remote string
+	// Fetch, if true, fetches the current branch from Remote before
+	// computing ahead/behind (only when CheckSynced is set).
+	Fetch bool
+	// Checks selects which checks to run; zero means CheckAll.
+	Checks StatusCheck
+}
+
+// GetStatus inspects the current working tree and, unless CheckSynced is
+// excluded from opts.Checks, its sync state against opts.Remote. It goes
+// through the current default Backend (see SetDefaultBackend) for every git
+// operation it performs.
+func GetStatus(ctx context.Context, opts StatusOpts) (*WorkingTreeStatus, error) {
+	checks := opts.Checks
+	if checks == 0 {
+		checks = CheckAll
+	}
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	backend := backendFor(nil)
+
+	st := &WorkingTreeStatus{}
+
+	if checks&CheckWorkingTree != 0 {
+		wtStatus, err := backend.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("status: %w", err)
+		}
+		st.Untracked = wtStatus.Untracked
+		st.Modified = wtStatus.Modified
+		st.Staged = wtStatus.Staged
+	}
+
+	if checks&CheckSynced != 0 {
+		headFullName, _, err := backend.ResolveRef(ctx, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("resolve HEAD: %w", err)
+		}
+		branch := strings.TrimPrefix(headFullName, "refs/heads/")
+
+		if opts.Fetch {
+			if err := backend.Fetch(ctx, remote, branch); err != nil {
+				return nil, fmt.Errorf("fetch: %w", err)
+			}
+		}
+
+		upstream, err := ResolveRef(ctx, remote+"/"+branch)
+		if err != nil {
+			return nil, fmt.Errorf("resolve upstream %s/%s: %w", remote, branch, err)
+		}
+		st.Upstream = upstream
+
+		ahead, err := backend.RevList(ctx, upstream.Name+".."+branch)
+		if err != nil {
+			return nil, fmt.Errorf("rev-list ahead: %w", err)
+		}
+		st.Ahead = len(ahead)
+
+		behind, err := backend.RevList(ctx, branch+".."+upstream.Name)
+		if err != nil {
+			return nil, fmt.Errorf("rev-list behind: %w", err)
+		}
+		st.Behind = len(behind)
+	}
+
+	return st, nil
+}
+
+// Sentinel errors returned by AssertBranchIsCleanAndSynced, checkable with
+// errors.Is.
+var (
+	ErrBranchBehind       = errors.New("branch is behind upstream")
+	ErrBranchAhead        = errors.New("branch is ahead of upstream")
+	ErrUncommittedChanges = errors.New("uncommitted changes present")
+)
+
+// AssertBranchIsCleanAndSynced is a convenience wrapper around GetStatus that
+// fails if the current branch has uncommitted changes or has diverged from
+// its upstream on "origin", fetching from origin first. The remote is not
+// configurable here; use GetStatus with StatusOpts.Remote for that.
+func AssertBranchIsCleanAndSynced(ctx context.Context) error {
+	st, err := GetStatus(ctx, StatusOpts{Fetch: true})
+	if err != nil {
+		return err
+	}
+
+	if st.Behind > 0 {
+		return fmt.Errorf("%w: %s", ErrBranchBehind, st.Upstream.Name)
+	}
+	if st.Ahead > 0 {
+		return fmt.Errorf("%w: %s", ErrBranchAhead, st.Upstream.Name)
+	}
+	if len(st.Untracked)+len(st.Modified)+len(st.Staged) > 0 {
+		return ErrUncommittedChanges
+	}
+	return nil
+}
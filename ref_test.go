@@ -0,0 +1,113 @@
+package gittoolbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+)
+
+// chdir switches the test process into dir for the duration of the test,
+// restoring the previous directory on cleanup. GetStatus/AssertBranchIsCleanAndSynced
+// operate on the process's current directory, same as the rest of this package.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	prev, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(prev); err != nil {
+			t.Fatalf("chdir back: %v", err)
+		}
+	})
+}
+
+// setupSyncedRepo creates a bare "origin" and a local clone tracking it, with
+// one commit pushed and fetched so "main" has an upstream.
+func setupSyncedRepo(t *testing.T) (local string) {
+	t.Helper()
+	origin := t.TempDir()
+	runGit(t, origin, "init", "--bare", "-b", "main")
+
+	local = t.TempDir()
+	runGit(t, local, "clone", origin, ".")
+	runGit(t, local, "config", "user.name", "Test")
+	runGit(t, local, "config", "user.email", "test@example.com")
+	writeAndCommit(t, local, "a.txt", "hello", "2025-09-15 10:00:00")
+	runGit(t, local, "push", "origin", "main")
+	runGit(t, local, "branch", "--set-upstream-to=origin/main", "main")
+
+	return local
+}
+
+func TestResolveRef_LocalBranch(t *testing.T) {
+	local := setupSyncedRepo(t)
+	chdir(t, local)
+
+	ref, err := ResolveRef(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("ResolveRef failed: %v", err)
+	}
+	if ref.Type != RefTypeLocalBranch {
+		t.Fatalf("expected RefTypeLocalBranch, got %v", ref.Type)
+	}
+	if ref.Sha == "" {
+		t.Fatalf("expected non-empty Sha")
+	}
+}
+
+func TestResolveRef_HEAD(t *testing.T) {
+	local := setupSyncedRepo(t)
+	chdir(t, local)
+
+	ref, err := ResolveRef(context.Background(), "HEAD")
+	if err != nil {
+		t.Fatalf("ResolveRef failed: %v", err)
+	}
+	if ref.Type != RefTypeHEAD {
+		t.Fatalf("expected RefTypeHEAD, got %v", ref.Type)
+	}
+}
+
+func TestGetStatus_Synced(t *testing.T) {
+	local := setupSyncedRepo(t)
+	chdir(t, local)
+
+	st, err := GetStatus(context.Background(), StatusOpts{})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if st.Ahead != 0 || st.Behind != 0 {
+		t.Fatalf("expected ahead=behind=0, got ahead=%d behind=%d", st.Ahead, st.Behind)
+	}
+	if len(st.Untracked)+len(st.Modified)+len(st.Staged) != 0 {
+		t.Fatalf("expected clean working tree, got %+v", st)
+	}
+}
+
+func TestAssertBranchIsCleanAndSynced_UncommittedChanges(t *testing.T) {
+	local := setupSyncedRepo(t)
+	chdir(t, local)
+
+	if err := writeFile(local+"/b.txt", "untracked"); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	err := AssertBranchIsCleanAndSynced(context.Background())
+	if !errors.Is(err, ErrUncommittedChanges) {
+		t.Fatalf("expected ErrUncommittedChanges, got %v", err)
+	}
+}
+
+func TestAssertBranchIsCleanAndSynced_Clean(t *testing.T) {
+	local := setupSyncedRepo(t)
+	chdir(t, local)
+
+	if err := AssertBranchIsCleanAndSynced(context.Background()); err != nil {
+		t.Fatalf("expected nil error for clean synced branch, got %v", err)
+	}
+}
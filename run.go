@@ -0,0 +1,113 @@
+package gittoolbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout applied to a Command when RunOpts.Timeout is
+// left at its zero value. Pass Timeout: -1 to disable the timeout entirely.
+const DefaultTimeout = 360 * time.Second
+
+// RunOpts controls how a Command executes: where it runs, what environment it
+// sees, how long it's allowed to run, and where its stdin/stdout/stderr go.
+type RunOpts struct {
+	Ctx     context.Context
+	Dir     string
+	Env     []string
+	Timeout time.Duration
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+
+	// Backend overrides the Backend used for this call; nil means
+	// SetDefaultBackend's current value. Only consulted by package
+	// functions that take a *RunOpts, such as GetVersionCommit.
+	Backend Backend
+}
+
+// Command is a git invocation built by NewCommand and executed with
+// RunStdString. Build it up with AddArguments/AddOptionValues/
+// AddDashesAndList/AddDynamicArguments rather than assembling a []string by
+// hand, so dynamic values can never be mistaken for flags.
+type Command struct {
+	ctx  context.Context
+	args []string
+}
+
+// NewCommand builds a git Command starting with the given trusted literal
+// args (e.g. "log", "-n", "1"). ctx is the fallback context used when
+// RunStdString is called without RunOpts.Ctx set; pass context.Background()
+// if the caller has no context to thread through.
+func NewCommand(ctx context.Context, args ...CmdArg) *Command {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c := &Command{ctx: ctx}
+	c.AddArguments(args...)
+	return c
+}
+
+// RunStdString executes the command and returns its trimmed stdout and
+// stderr separately, so callers parsing stdout never see stderr warnings
+// mixed in. opts may be nil to accept all defaults.
+func (c *Command) RunStdString(opts *RunOpts) (string, string, error) {
+	if opts == nil {
+		opts = &RunOpts{}
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = c.ctx
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "git", c.args...)
+	if opts.Dir != "" {
+		cmd.Dir = opts.Dir
+	}
+	if opts.Env != nil {
+		cmd.Env = opts.Env
+	}
+	if opts.Stdin != nil {
+		cmd.Stdin = opts.Stdin
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if opts.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(&stdout, opts.Stdout)
+	}
+	if opts.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(&stderr, opts.Stderr)
+	}
+
+	err := cmd.Run()
+	outStr := strings.TrimSpace(stdout.String())
+	errStr := strings.TrimSpace(stderr.String())
+	if err != nil {
+		if ctx.Err() != nil {
+			return outStr, errStr, fmt.Errorf("git %s: %w", strings.Join(c.args, " "), ctx.Err())
+		}
+		if errStr != "" {
+			return outStr, errStr, fmt.Errorf("git %s: %w: %s", strings.Join(c.args, " "), err, errStr)
+		}
+		return outStr, errStr, fmt.Errorf("git %s: %w", strings.Join(c.args, " "), err)
+	}
+	return outStr, errStr, nil
+}
@@ -0,0 +1,33 @@
+package gittoolbox
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommand_RunStdString_SeparatesStdoutAndStderr(t *testing.T) {
+	out, stderr, err := NewCommand(context.Background(), "rev-parse", "--is-inside-work-tree", "--bogus-flag").RunStdString(&RunOpts{Dir: t.TempDir()})
+	if err == nil {
+		t.Fatalf("expected error for invalid invocation outside a repo, got stdout=%q stderr=%q", out, stderr)
+	}
+	if stderr == "" {
+		t.Fatalf("expected non-empty stderr, got err=%v", err)
+	}
+}
+
+func TestCommand_RunStdString_TimeoutCancelsSlowCommand(t *testing.T) {
+	td := t.TempDir()
+	initRepo(t, td)
+	writeAndCommit(t, td, "a.txt", "hello", "2025-09-10 12:00:00")
+
+	_, _, err := NewCommand(context.Background(), "log", "--follow", "-n", "1").
+		RunStdString(&RunOpts{Dir: td, Timeout: time.Nanosecond})
+	if err == nil {
+		t.Fatalf("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("expected deadline exceeded error, got %v", err)
+	}
+}